@@ -0,0 +1,140 @@
+// Package audio wraps github.com/hajimehoshi/ebiten/v2/audio to load the
+// game's SFX and music once at startup and play them back by name.
+package audio
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	eaudio "github.com/hajimehoshi/ebiten/v2/audio"
+	"github.com/hajimehoshi/ebiten/v2/audio/vorbis"
+	"github.com/hajimehoshi/ebiten/v2/audio/wav"
+)
+
+const sampleRate = 44100
+
+var (
+	ctx    = eaudio.NewContext(sampleRate)
+	sounds = map[string]*eaudio.Player{}
+	muted  atomic.Bool
+)
+
+// Load decodes every .wav/.ogg file in dir into a ready-to-play
+// *audio.Player keyed by its base filename (e.g. "flap.wav" -> "flap").
+// Call once at startup before any PlaySFX/PlayMusic call.
+func Load(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		ext := strings.ToLower(filepath.Ext(name))
+		if ext != ".wav" && ext != ".ogg" {
+			continue
+		}
+
+		// Read the whole file up front instead of decoding straight off
+		// an *os.File: the decoders just need an io.ReadSeeker, and this
+		// way the file handle is gone before the sound is ever played
+		// instead of staying open for the life of the process.
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return err
+		}
+
+		var stream io.ReadSeeker
+		switch ext {
+		case ".wav":
+			decoded, err := wav.DecodeWithoutResampling(bytes.NewReader(data))
+			if err != nil {
+				return fmt.Errorf("decode %s: %w", name, err)
+			}
+			stream = decoded
+		case ".ogg":
+			decoded, err := vorbis.DecodeWithoutResampling(bytes.NewReader(data))
+			if err != nil {
+				return fmt.Errorf("decode %s: %w", name, err)
+			}
+			stream = decoded
+		}
+
+		player, err := ctx.NewPlayer(stream)
+		if err != nil {
+			return fmt.Errorf("new player %s: %w", name, err)
+		}
+		sounds[strings.TrimSuffix(name, ext)] = player
+	}
+
+	return nil
+}
+
+// PlaySFX plays a one-shot sound by name, restarting it from the
+// beginning if it's already playing. It is silent if SetMuted(true) was
+// called or the name wasn't loaded.
+func PlaySFX(name string) {
+	player, ok := sounds[name]
+	if !ok || muted.Load() {
+		return
+	}
+	player.Rewind()
+	player.Play()
+}
+
+// PlayMusic plays (or loops) a named track. Looping tracks run in their
+// own goroutine for the life of the process; while muted, a pass already
+// playing runs to its end and the track is not restarted until unmuted.
+func PlayMusic(name string, loop bool) {
+	player, ok := sounds[name]
+	if !ok {
+		return
+	}
+	if loop {
+		go loopPlayer(player)
+		return
+	}
+	if muted.Load() {
+		return
+	}
+	player.Rewind()
+	player.Play()
+}
+
+// loopPlayer restarts player every time it finishes, for background
+// music. It never returns, since mute is meant to pause it rather than
+// kill it: the caller needs PlayMusic("music", true) to still be in
+// effect whenever SetMuted(false) is called later.
+func loopPlayer(player *eaudio.Player) {
+	for {
+		if muted.Load() {
+			time.Sleep(100 * time.Millisecond)
+			continue
+		}
+		player.Rewind()
+		player.Play()
+		for player.IsPlaying() {
+			time.Sleep(100 * time.Millisecond)
+		}
+	}
+}
+
+// SetMuted mutes or unmutes all future PlaySFX/PlayMusic calls. Already
+// playing sounds are not stopped.
+func SetMuted(m bool) {
+	muted.Store(m)
+}
+
+// Muted reports the current mute state.
+func Muted() bool {
+	return muted.Load()
+}
@@ -0,0 +1,39 @@
+package main
+
+import "image"
+
+// wingFrames is the bird's flap animation cycle. The up-stroke (frame 1)
+// gets a taller hurtbox than the neutral frames since the wingtip extends
+// furthest from the body at that point in the flap.
+var wingFrames = []FrameData{
+	{{T: HitboxHurt, R: image.Rect(0, 0, birdSize, birdSize)}},
+	{{T: HitboxHurt, R: image.Rect(0, -6, birdSize, birdSize)}},
+	{{T: HitboxHurt, R: image.Rect(0, 0, birdSize, birdSize)}},
+}
+
+// Bird is the player-controlled entity.
+type Bird struct {
+	x, y  float64
+	dy    float64
+	frame int
+
+	// invincible is set while the bird's garlic power-up is active. It
+	// makes CurrentFrame report HitboxNormal instead of HitboxHurt, so
+	// damages() sees a harmless overlap instead of a hit.
+	invincible bool
+}
+
+// CurrentFrame implements Entity, translating the active wing frame's
+// hitboxes by the bird's world position.
+func (b *Bird) CurrentFrame() []Hitbox {
+	data := wingFrames[b.frame%len(wingFrames)]
+	boxes := make([]Hitbox, len(data))
+	for i, h := range data {
+		t := h.T
+		if b.invincible && t == HitboxHurt {
+			t = HitboxNormal
+		}
+		boxes[i] = Hitbox{T: t, R: h.R.Add(image.Pt(int(b.x), int(b.y)))}
+	}
+	return boxes
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/gorilla/websocket"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+// ClientGame renders the world streamed from a ServerGame over /join,
+// predicting the local player's own bird ahead of the next snapshot so a
+// flap feels instant despite network latency, then snapping the bird's
+// height to the server's value whenever a newer snapshot arrives.
+// Snapshots are not matched to the client frame they answer.
+type ClientGame struct {
+	conn       *websocket.Conn
+	slot       int // which of Snapshot's two birds is ours, from JoinAck
+	localBird  *Bird
+	frameCount int
+	remote     Snapshot
+	nextSeq    uint64 // lowest snapshot Seq not yet applied
+	snapshots  chan Snapshot
+}
+
+// NewClientGame starts streaming snapshots from an already-joined
+// connection. slot is the player slot HandleJoin assigned this
+// connection, telling Update which Snapshot field to reconcile against.
+func NewClientGame(conn *websocket.Conn, slot int) *ClientGame {
+	c := &ClientGame{
+		conn:      conn,
+		slot:      slot,
+		localBird: &Bird{x: 50, y: screenHeight / 2},
+		snapshots: make(chan Snapshot, 8),
+	}
+	go c.readLoop()
+	return c
+}
+
+// birdY picks the snapshot field for the given player slot.
+func birdY(snap Snapshot, slot int) float64 {
+	if slot == 0 {
+		return snap.Bird1Y
+	}
+	return snap.Bird2Y
+}
+
+// readLoop decodes snapshots off the connection onto a channel so Update
+// never blocks on the network.
+func (c *ClientGame) readLoop() {
+	for {
+		var snap Snapshot
+		if err := c.conn.ReadJSON(&snap); err != nil {
+			close(c.snapshots)
+			return
+		}
+		c.snapshots <- snap
+	}
+}
+
+// Update implements ebiten.Game.
+func (c *ClientGame) Update() error {
+	flap := ebiten.IsKeyPressed(ebiten.KeySpace) || ebiten.IsKeyPressed(ebiten.KeyArrowUp)
+
+	// Predict the local bird immediately; the next newer snapshot from
+	// the server overwrites its height.
+	c.localBird.dy += 0.1
+	if flap {
+		c.localBird.dy = -2
+	}
+	c.localBird.y += c.localBird.dy
+
+	c.frameCount++
+	if err := c.conn.WriteJSON(InputFrame{Frame: c.frameCount, Flap: flap}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case snap, ok := <-c.snapshots:
+			if !ok {
+				return nil
+			}
+			if snap.Seq < c.nextSeq {
+				continue // stale or duplicate packet
+			}
+			c.nextSeq = snap.Seq + 1
+			c.remote = snap
+			c.localBird.y = birdY(snap, c.slot)
+		default:
+			return nil
+		}
+	}
+}
+
+// Draw implements ebiten.Game.
+func (c *ClientGame) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{75, 145, 201, 1})
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(c.localBird.x, c.localBird.y)
+	screen.DrawImage(birdImage, op)
+
+	op2 := &ebiten.DrawImageOptions{}
+	op2.GeoM.Translate(50, birdY(c.remote, 1-c.slot))
+	screen.DrawImage(birdImage, op2)
+
+	for _, pillar := range c.remote.Pillars {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(float64(pillar.X), float64(pillar.Y))
+		screen.DrawImage(pillarImage, op)
+		op.GeoM.Translate(0, float64(pillar.Gap)+pillarWidth)
+		screen.DrawImage(pillarImage, op)
+	}
+
+	ebitenutil.DebugPrint(screen, fmt.Sprintf("Score: %d", c.remote.Score))
+	if c.remote.GameOver {
+		ebitenutil.DebugPrint(screen, "GAME OVER")
+	}
+}
+
+// Layout implements ebiten.Game.
+func (c *ClientGame) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return screenWidth, screenHeight
+}
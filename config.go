@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// configFileName is kept next to the executable so mute state survives
+// between runs without needing a home-directory config path.
+const configFileName = "flappybird.config.json"
+
+// Config is the small set of user preferences that persist across runs.
+type Config struct {
+	Muted bool `json:"muted"`
+}
+
+// configPath resolves to configFileName alongside the running binary.
+func configPath() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(exe), configFileName), nil
+}
+
+// loadConfig reads the config file, returning a zero-value Config if it
+// doesn't exist yet.
+func loadConfig() Config {
+	path, err := configPath()
+	if err != nil {
+		return Config{}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}
+	}
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}
+	}
+	return cfg
+}
+
+// Save writes the config file next to the executable.
+func (c Config) Save() error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
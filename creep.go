@@ -0,0 +1,156 @@
+package main
+
+import (
+	"image"
+	"math"
+	"math/rand/v2"
+)
+
+const (
+	// maxCreeps bounds how many creeps can be alive at once. It's set
+	// high enough that a plain O(n^2) scan against the bird and against
+	// each other would actually get expensive, which is what the
+	// CreepGrid spatial hash below is for.
+	maxCreeps = 200
+	creepSize = 28
+
+	// creepChaseDist is how close the bird has to get before an idle
+	// creep switches into chase.
+	creepChaseDist = 160
+
+	// creepGridCell sizes the spatial hash's buckets; it should be on
+	// the order of the chase radius so a query only has to look at the
+	// bird's own cell and its immediate neighbors.
+	creepGridCell = 160
+)
+
+// creepAction is the behavior state driven by the tick-based scheduler.
+type creepAction int
+
+const (
+	actionWander creepAction = iota
+	actionChase
+	actionFlee
+)
+
+// Creep is a bat/ghost obstacle that drifts, chases, or flees the bird.
+// Its behavior is driven by a tick-based scheduler rather than
+// re-deciding every frame. Its randomness comes from rnd, a
+// source split off the level's seed, so a replay reproduces the exact
+// same creeps instead of diverging from the original run.
+type Creep struct {
+	x, y         float64
+	moveX, moveY float64
+	tick         int
+	nextAction   int
+	action       creepAction
+	rnd          *rand.Rand
+}
+
+// NewCreep spawns a creep at the given point and arms its first action
+// timer, drawing all of its randomness from rnd.
+func NewCreep(x, y float64, rnd *rand.Rand) *Creep {
+	c := &Creep{x: x, y: y, rnd: rnd}
+	c.scheduleNextAction()
+	return c
+}
+
+// CurrentFrame implements Entity. Creeps have no animation, just a single
+// hurt box matching their sprite.
+func (c *Creep) CurrentFrame() []Hitbox {
+	r := image.Rect(0, 0, creepSize, creepSize).Add(image.Pt(int(c.x), int(c.y)))
+	return []Hitbox{{T: HitboxHurt, R: r}}
+}
+
+// scheduleNextAction arms the next decision 144-864 frames out (roughly
+// 2.4-14.4 seconds at 60fps).
+func (c *Creep) scheduleNextAction() {
+	c.tick = 0
+	c.nextAction = 144 + c.rnd.IntN(864-144+1)
+}
+
+// Update advances the creep's scheduler and moves it along its current
+// heading. garlicActive is only read when the scheduled decision comes
+// due; it then forces flee regardless of distance to the bird.
+func (c *Creep) Update(bird *Bird, garlicActive bool) {
+	c.tick++
+	if c.tick >= c.nextAction {
+		c.doNextAction(bird, garlicActive)
+	}
+	c.x += c.moveX
+	c.y += c.moveY
+}
+
+// doNextAction picks the creep's next behavior and heading based on its
+// distance to the bird.
+func (c *Creep) doNextAction(bird *Bird, garlicActive bool) {
+	dx := bird.x - c.x
+	dy := bird.y - c.y
+
+	switch {
+	case garlicActive:
+		c.action = actionFlee
+	case math.Hypot(dx, dy) < creepChaseDist:
+		c.action = actionChase
+	default:
+		c.action = actionWander
+	}
+
+	switch c.action {
+	case actionChase:
+		c.moveX, c.moveY = heading(dx, dy, 1.2)
+	case actionFlee:
+		c.moveX, c.moveY = heading(-dx, -dy, 1.6)
+	default:
+		c.moveX = float64(c.rnd.IntN(3) - 1)
+		c.moveY = float64(c.rnd.IntN(3) - 1)
+	}
+
+	c.scheduleNextAction()
+}
+
+// heading returns (dx, dy) scaled to the given speed, or (0, 0) if the
+// vector is degenerate.
+func heading(dx, dy, speed float64) (float64, float64) {
+	d := math.Hypot(dx, dy)
+	if d == 0 {
+		return 0, 0
+	}
+	return dx / d * speed, dy / d * speed
+}
+
+// CreepGrid is a coarse spatial hash over live creeps, so the bird's
+// collision check only has to touch creeps near the point of interest
+// instead of scanning the whole slice every frame. Creep AI does not use
+// it; each creep measures its distance to the bird directly.
+type CreepGrid struct {
+	cells map[image.Point][]*Creep
+}
+
+// NewCreepGrid builds an empty grid ready for a frame's worth of creeps.
+func NewCreepGrid() *CreepGrid {
+	return &CreepGrid{cells: make(map[image.Point][]*Creep)}
+}
+
+func cellOf(x, y float64) image.Point {
+	return image.Pt(int(x)/creepGridCell, int(y)/creepGridCell)
+}
+
+// Insert buckets a creep by its current cell.
+func (g *CreepGrid) Insert(c *Creep) {
+	cell := cellOf(c.x, c.y)
+	g.cells[cell] = append(g.cells[cell], c)
+}
+
+// Near returns every creep in the cell containing (x, y) and its eight
+// neighbors.
+func (g *CreepGrid) Near(x, y float64) []*Creep {
+	center := cellOf(x, y)
+	var found []*Creep
+	for dx := -1; dx <= 1; dx++ {
+		for dy := -1; dy <= 1; dy++ {
+			found = append(found, g.cells[image.Pt(center.X+dx, center.Y+dy)]...)
+		}
+	}
+	return found
+}
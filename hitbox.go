@@ -0,0 +1,54 @@
+package main
+
+import "image"
+
+// HitboxType classifies a frame-data rectangle the way fighting games do:
+// a hurt box takes part in damage (the bird's can be hit, a pillar's or
+// creep's deals the hit), a normal box only overlaps. Collide reports
+// both sides' types so callers can tell a real hit from a harmless
+// overlap with damages.
+type HitboxType int
+
+const (
+	HitboxNormal HitboxType = iota
+	HitboxHurt
+)
+
+// Hitbox is a single frame-data rectangle tagged with its collision role.
+type Hitbox struct {
+	T HitboxType
+	R image.Rectangle
+}
+
+// FrameData is the set of hitboxes active on one animation frame, in
+// entity-local coordinates.
+type FrameData []Hitbox
+
+// Entity is anything that can take part in frame-data collision checks.
+type Entity interface {
+	// CurrentFrame returns the hitboxes active this frame, already
+	// translated to world space.
+	CurrentFrame() []Hitbox
+}
+
+// Collide checks every hitbox of a against every hitbox of b and returns
+// the types of the first overlapping pair. ok is false if nothing
+// overlaps this frame.
+func Collide(a, b Entity) (aType, bType HitboxType, ok bool) {
+	for _, ha := range a.CurrentFrame() {
+		for _, hb := range b.CurrentFrame() {
+			if ha.R.Overlaps(hb.R) {
+				return ha.T, hb.T, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// damages reports whether an overlap between these two hitbox types
+// should deal damage. Both sides have to be hurt boxes: an invincible
+// bird reports HitboxNormal instead of HitboxHurt, so the same overlap
+// that would normally kill it is harmless.
+func damages(aType, bType HitboxType) bool {
+	return aType == HitboxHurt && bType == HitboxHurt
+}
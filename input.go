@@ -0,0 +1,59 @@
+package main
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// InputSource decouples Game.Update from ebiten's key state so a replay
+// can drive the exact same update loop from a recorded script instead of
+// a live keyboard. frame is the game's tick counter, letting a scripted
+// source look up what was held on that exact frame.
+type InputSource interface {
+	SpaceDown(frame int) bool
+	EnterDown(frame int) bool
+	GarlicDown(frame int) bool
+}
+
+// liveInput reads the real keyboard; it ignores frame.
+type liveInput struct{}
+
+func (liveInput) SpaceDown(int) bool {
+	return ebiten.IsKeyPressed(ebiten.KeySpace) || ebiten.IsKeyPressed(ebiten.KeyArrowUp)
+}
+
+func (liveInput) EnterDown(int) bool {
+	return ebiten.IsKeyPressed(ebiten.KeyEnter)
+}
+
+func (liveInput) GarlicDown(int) bool {
+	return ebiten.IsKeyPressed(ebiten.KeyG)
+}
+
+// scriptedInput replays a recorded Replay in place of the keyboard.
+type scriptedInput struct {
+	space  map[int]bool
+	enter  map[int]bool
+	garlic map[int]bool
+}
+
+// newScriptedInput builds a scriptedInput from a replay's recorded
+// frames.
+func newScriptedInput(rep Replay) *scriptedInput {
+	s := &scriptedInput{
+		space:  make(map[int]bool, len(rep.Space)),
+		enter:  make(map[int]bool, len(rep.Enter)),
+		garlic: make(map[int]bool, len(rep.Garlic)),
+	}
+	for _, f := range rep.Space {
+		s.space[f] = true
+	}
+	for _, f := range rep.Enter {
+		s.enter[f] = true
+	}
+	for _, f := range rep.Garlic {
+		s.garlic[f] = true
+	}
+	return s
+}
+
+func (s *scriptedInput) SpaceDown(frame int) bool  { return s.space[frame] }
+func (s *scriptedInput) EnterDown(frame int) bool  { return s.enter[frame] }
+func (s *scriptedInput) GarlicDown(frame int) bool { return s.garlic[frame] }
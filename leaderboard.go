@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// leaderboardSize caps how many entries are kept; only the best runs
+// matter for a daily-challenge board.
+const leaderboardSize = 10
+
+// errSeedMismatch is returned by Add when a submitted replay was recorded
+// against a different level seed than today's challenge, so it can't be
+// ranked fairly alongside the rest of the board.
+var errSeedMismatch = errors.New("replay seed does not match today's daily challenge")
+
+// Leaderboard keeps the best replays submitted for a single level seed,
+// i.e. one day's challenge.
+type Leaderboard struct {
+	mu      sync.Mutex
+	seed    int64
+	entries []Replay
+}
+
+// NewLeaderboard returns an empty leaderboard scoped to seed.
+func NewLeaderboard(seed int64) *Leaderboard {
+	return &Leaderboard{seed: seed}
+}
+
+// Add inserts a replay, keeping entries sorted by score descending and
+// trimmed to leaderboardSize. It rejects replays recorded against a
+// different level seed.
+func (lb *Leaderboard) Add(r Replay) error {
+	if r.Seed != lb.seed {
+		return errSeedMismatch
+	}
+
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	lb.entries = append(lb.entries, r)
+	sort.Slice(lb.entries, func(i, j int) bool {
+		return lb.entries[i].Score > lb.entries[j].Score
+	})
+	if len(lb.entries) > leaderboardSize {
+		lb.entries = lb.entries[:leaderboardSize]
+	}
+	return nil
+}
+
+// Top returns a copy of the current leaderboard.
+func (lb *Leaderboard) Top() []Replay {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	top := make([]Replay, len(lb.entries))
+	copy(top, lb.entries)
+	return top
+}
+
+// HandleLeaderboard serves the current leaderboard on GET and accepts a
+// replay submission as a JSON body on POST.
+func (lb *Leaderboard) HandleLeaderboard(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		json.NewEncoder(w).Encode(lb.Top())
+	case http.MethodPost:
+		var rep Replay
+		if err := json.NewDecoder(r.Body).Decode(&rep); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := lb.Add(rep); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// seedPCG expands a single int64 seed into the two-word state PCG needs,
+// so the rest of the codebase can keep passing around one seed value
+// (for SetSeed, replays, daily challenges) while the RNG itself is an
+// explicit, capturable math/rand/v2 source.
+func seedPCG(seed int64) *rand.PCG {
+	hi := uint64(seed)
+	lo := uint64(seed) ^ 0x9e3779b97f4a7c15
+	return rand.NewPCG(hi, lo)
+}
+
+// Biome controls the background color and gap behavior for a segment of
+// pillars, so difficulty and mood shift in readable chunks rather than
+// from pure per-pillar randomness.
+type Biome int
+
+const (
+	BiomeDay Biome = iota
+	BiomeSunset
+	BiomeCave
+	BiomeStorm
+)
+
+// biomeOrder is the fixed rotation segments cycle through.
+var biomeOrder = []Biome{BiomeDay, BiomeSunset, BiomeCave, BiomeStorm}
+
+// biomeSegmentLength is how many pillars make up one segment before the
+// generator rolls over to the next biome.
+const biomeSegmentLength = 30
+
+// gapRange is the [min, max] pillar gap a biome will generate.
+type gapRange struct {
+	min, max int
+}
+
+var biomeGapRanges = map[Biome]gapRange{
+	BiomeDay:    {min: 180, max: 220},
+	BiomeSunset: {min: 160, max: 210},
+	BiomeCave:   {min: 140, max: 180},
+	BiomeStorm:  {min: 120, max: 170},
+}
+
+// biomeBackgrounds gives Game.Draw something to fill the screen with per
+// biome.
+var biomeBackgrounds = map[Biome]struct{ r, g, b uint8 }{
+	BiomeDay:    {75, 145, 201},
+	BiomeSunset: {201, 120, 75},
+	BiomeCave:   {40, 40, 55},
+	BiomeStorm:  {60, 65, 80},
+}
+
+// spawnInterval is how many frames pass between pillars. It matches the
+// pillar's travel speed so successive gaps stay evenly spaced on screen.
+const spawnInterval = screenWidth / pillarSpeed
+
+// LevelGenerator produces a deterministic, seeded stream of pillars
+// grouped into themed biome segments. Successive gaps drift vertically
+// within a biome's range instead of jumping randomly, so the difficulty
+// curve feels smooth.
+type LevelGenerator struct {
+	seed       int64
+	rnd        *rand.Rand
+	creepRnd   *rand.Rand
+	segment    int
+	segmentPos int
+	lastGapY   int
+	spawnTick  int
+}
+
+// NewLevelGenerator creates a generator seeded for reproducible runs.
+func NewLevelGenerator(seed int64) *LevelGenerator {
+	lg := &LevelGenerator{}
+	lg.SetSeed(seed)
+	return lg
+}
+
+// SetSeed reseeds the generator and restarts it at the first biome
+// segment, for daily-challenge mode or replays.
+func (lg *LevelGenerator) SetSeed(seed int64) {
+	lg.seed = seed
+	lg.rnd = rand.New(seedPCG(seed))
+	// creepRnd is a separate stream off the same seed (twisted so it
+	// doesn't just mirror rnd's sequence), so creep spawn timing and AI
+	// reproduce exactly across a replay without perturbing pillar gaps.
+	lg.creepRnd = rand.New(seedPCG(seed + 1))
+	lg.segment = 0
+	lg.segmentPos = 0
+	lg.lastGapY = screenHeight / 2
+	lg.spawnTick = 0
+}
+
+// CreepRand returns the seeded RNG creep spawning and AI should draw
+// from, so their randomness reproduces across a replay the same way
+// pillar generation does.
+func (lg *LevelGenerator) CreepRand() *rand.Rand {
+	return lg.creepRnd
+}
+
+// Restart replays the generator's current seed from the beginning, so a
+// game reset produces the same level again.
+func (lg *LevelGenerator) Restart() {
+	lg.SetSeed(lg.seed)
+}
+
+// dailySeed derives a level seed from a calendar date (UTC), so every
+// player who starts the daily challenge on the same day plays the same
+// pillar layout and can be fairly ranked against each other on the
+// leaderboard.
+func dailySeed(t time.Time) int64 {
+	y, m, d := t.UTC().Date()
+	return int64(y)*10000 + int64(m)*100 + int64(d)
+}
+
+// Biome reports the biome the generator is currently emitting pillars for.
+func (lg *LevelGenerator) Biome() Biome {
+	return biomeOrder[lg.segment%len(biomeOrder)]
+}
+
+// Tick advances the generator's internal clock by one frame and returns
+// a newly spawned pillar when spawnInterval has elapsed, or nil
+// otherwise. Callers just call this every frame instead of hardcoding
+// the spawn-spacing math themselves.
+func (lg *LevelGenerator) Tick() *Pillar {
+	lg.spawnTick++
+	if lg.spawnTick%spawnInterval != 0 {
+		return nil
+	}
+	return lg.next()
+}
+
+// next pulls the next pillar from the stream, advancing the segment
+// cursor and rolling over to the next biome every biomeSegmentLength
+// pillars.
+func (lg *LevelGenerator) next() *Pillar {
+	gr := biomeGapRanges[lg.Biome()]
+	gap := gr.min + lg.rnd.IntN(gr.max-gr.min+1)
+
+	drift := lg.rnd.IntN(81) - 40 // +/-40px drift from the previous gap
+	y := lg.lastGapY + drift
+	if y < 0 {
+		y = 0
+	}
+	if max := screenHeight - gap - pillarWidth; y > max {
+		y = max
+	}
+	lg.lastGapY = y
+
+	lg.segmentPos++
+	if lg.segmentPos >= biomeSegmentLength {
+		lg.segmentPos = 0
+		lg.segment++
+	}
+
+	return &Pillar{X: screenWidth, Y: y, Gap: gap}
+}
@@ -1,16 +1,20 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"image/color"
 	"log"
-	"math/rand"
+	"math/rand/v2"
 	"net/http"
 	"sync"
 	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+
+	"github.com/Aadhyadarshan/GOLang-Flappy-Bird-Game/audio"
 )
 
 const (
@@ -18,103 +22,212 @@ const (
 	screenHeight = 630
 	birdSize     = 40
 	pillarWidth  = 200
-	pillarGap    = 200
 	pillarSpeed  = 3
 )
 
 var (
 	birdImage   *ebiten.Image
 	pillarImage *ebiten.Image
+	creepImage  *ebiten.Image
 )
 
-// Pillar represents a pillar object.
-type Pillar struct {
-	x, y int
-}
-
 // Game represents the game state.
 type Game struct {
-	mu         sync.Mutex
-	birdY      float64
-	birdDY     float64
-	pillars    []*Pillar
-	frameCount int
-	isGameOver bool
-	score      int
-	started    bool
+	mu          sync.Mutex
+	bird        *Bird
+	levelGen    *LevelGenerator
+	pillars     []*Pillar
+	creeps      []*Creep
+	garlicTimer int
+	isGameOver  bool
+	score       int
+	started     bool
+
+	input          InputSource
+	tick           int
+	recording      bool    // false while replaying a recorded run
+	replay         *Replay // nil once the current run's replay has been saved
+	leaderboardURL string
+
+	prevSpace bool // for flap-on-keydown-edge, not IsKeyPressed repeat
+	prevMute  bool
+}
+
+// SetSeed reseeds the level generator immediately, for pinning a run to
+// the daily-challenge seed before play starts.
+func (g *Game) SetSeed(seed int64) {
+	g.levelGen.SetSeed(seed)
 }
 
-// Update updates the game state.
+// Update updates the game state. All key reads go through g.input so a
+// replay can drive this exact same loop from a recorded script.
 func (g *Game) Update() error {
+	g.tick++
+	space := g.input.SpaceDown(g.tick)
+	enter := g.input.EnterDown(g.tick)
+	garlic := g.input.GarlicDown(g.tick)
+	if g.replay != nil {
+		g.replay.record(g.tick, space, enter, garlic)
+	}
+
+	flapEdge := space && !g.prevSpace
+	g.prevSpace = space
+
+	// Mute is a local preference, not part of the replay script, so it
+	// reads the real keyboard directly.
+	muteDown := ebiten.IsKeyPressed(ebiten.KeyM)
+	if muteDown && !g.prevMute {
+		audio.SetMuted(!audio.Muted())
+		if err := (Config{Muted: audio.Muted()}).Save(); err != nil {
+			log.Println("save config:", err)
+		}
+	}
+	g.prevMute = muteDown
+
 	if !g.isGameOver && !g.started {
-		if ebiten.IsKeyPressed(ebiten.KeySpace) || ebiten.IsKeyPressed(ebiten.KeyArrowUp) {
+		if space {
 			g.started = true
 		}
 	}
 
 	if g.started && !g.isGameOver {
 		// Apply gravity to bird's vertical velocity
-		g.birdDY += 0.1
+		g.bird.dy += 0.1
+		g.bird.frame = 0
 
 		// Update bird position
-		if ebiten.IsKeyPressed(ebiten.KeySpace) || ebiten.IsKeyPressed(ebiten.KeyArrowUp) {
-			g.birdDY = -2
+		if space {
+			g.bird.dy = -2
+			g.bird.frame = 1
+			if flapEdge {
+				audio.PlaySFX("flap")
+			}
+		}
+		g.bird.y += g.bird.dy
+
+		// Generate new pillars; the generator owns its own spawn spacing
+		if pillar := g.levelGen.Tick(); pillar != nil {
+			g.pillars = append(g.pillars, pillar)
+			creepRnd := g.levelGen.CreepRand()
+			if len(g.creeps) < maxCreeps && creepRnd.IntN(2) == 0 {
+				g.creeps = append(g.creeps, NewCreep(float64(pillar.X)+pillarWidth/2, float64(pillar.Y)+float64(pillar.Gap)/2, creepRnd))
+			}
 		}
-		g.birdY += g.birdDY
 
-		// Generate new pillars
-		g.frameCount++
-		if g.frameCount%(screenWidth/pillarSpeed) == 0 {
-			g.pillars = append(g.pillars, NewPillar())
+		// Garlic grants temporary invincibility: it swaps the bird's
+		// hurtboxes to HitboxNormal so damages() treats every overlap
+		// below, pillar or creep, as harmless.
+		if garlic {
+			g.garlicTimer = 300
 		}
+		if g.garlicTimer > 0 {
+			g.garlicTimer--
+		}
+		garlicActive := g.garlicTimer > 0
+		g.bird.invincible = garlicActive
 
 		// Update pillar positions
 		for _, pillar := range g.pillars {
-			pillar.x -= pillarSpeed
-			if pillar.x < -pillarWidth {
+			pillar.X -= pillarSpeed
+			if pillar.X < -pillarWidth {
 				g.pillars = g.pillars[1:]
 				g.score++
+				audio.PlaySFX("score")
+			}
+
+			// Check collision via frame-data hitboxes
+			if aType, bType, hit := Collide(g.bird, pillar); hit && damages(aType, bType) {
+				g.isGameOver = true
+				audio.PlaySFX("hit")
 			}
+		}
 
-			// Check collision
-			if g.birdY < float64(pillar.y) || g.birdY > float64(pillar.y+pillarGap) {
-				if pillar.x < birdSize && pillar.x > -pillarWidth {
-					g.isGameOver = true
-				}
+		// Update creeps and check their hurtboxes against the bird,
+		// using the spatial grid so we only test creeps near it.
+		grid := NewCreepGrid()
+		for i := 0; i < len(g.creeps); {
+			creep := g.creeps[i]
+			creep.Update(g.bird, garlicActive)
+			creep.x -= pillarSpeed
+			if creep.x < -pillarWidth {
+				g.creeps = append(g.creeps[:i], g.creeps[i+1:]...)
+				continue
 			}
+			grid.Insert(creep)
+			i++
+		}
+		for _, creep := range grid.Near(g.bird.x, g.bird.y) {
+			if aType, bType, hit := Collide(g.bird, creep); hit && damages(aType, bType) {
+				g.isGameOver = true
+				audio.PlaySFX("hit")
+			}
+		}
+
+		if g.isGameOver {
+			audio.PlaySFX("die")
+			g.finishReplay()
 		}
 	}
 
-	if g.isGameOver && ebiten.IsKeyPressed(ebiten.KeyEnter) {
+	if g.isGameOver && enter {
 		g.Reset()
 	}
 
 	return nil
 }
 
+// finishReplay saves the just-finished run's replay to disk and, if a
+// leaderboard URL was configured, submits it alongside the final score.
+// It is a no-op while replaying a recorded run (g.replay is nil then).
+// The leaderboard submission runs on its own goroutine: it's an HTTP
+// round trip, and Update can't afford to block on the network every
+// frame while it waits.
+func (g *Game) finishReplay() {
+	if g.replay == nil {
+		return
+	}
+	g.replay.Score = g.score
+	if err := g.replay.Save("replay.json"); err != nil {
+		log.Println("save replay:", err)
+	}
+	if g.leaderboardURL != "" {
+		rep := g.replay
+		go postLeaderboard(g.leaderboardURL, rep)
+	}
+	g.replay = nil
+}
+
 // Draw draws the game.
 func (g *Game) Draw(screen *ebiten.Image) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
-	screen.Fill(color.RGBA{75, 145, 201, 1})
+	bg := biomeBackgrounds[g.levelGen.Biome()]
+	screen.Fill(color.RGBA{bg.r, bg.g, bg.b, 1})
 
 	// Draw bird
 	op := &ebiten.DrawImageOptions{}
-	op.GeoM.Translate(50, g.birdY)
+	op.GeoM.Translate(g.bird.x, g.bird.y)
 	screen.DrawImage(birdImage, op)
 
 	// Draw pillars
 	for _, pillar := range g.pillars {
 		op := &ebiten.DrawImageOptions{}
-		op.GeoM.Translate(float64(pillar.x), float64(pillar.y))
+		op.GeoM.Translate(float64(pillar.X), float64(pillar.Y))
 		screen.DrawImage(pillarImage, op)
 
 		// Draw second pillar
-		op.GeoM.Translate(0, float64(pillarGap)+pillarWidth)
+		op.GeoM.Translate(0, float64(pillar.Gap)+pillarWidth)
 		screen.DrawImage(pillarImage, op)
 	}
 
+	// Draw creeps
+	for _, creep := range g.creeps {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(creep.x, creep.y)
+		screen.DrawImage(creepImage, op)
+	}
+
 	// Display score
 	ebitenutil.DebugPrint(screen, fmt.Sprintf("Score: %d", g.score))
 
@@ -134,32 +247,91 @@ func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
 	return screenWidth, screenHeight
 }
 
-// NewPillar creates a new pillar object with random height.
-func NewPillar() *Pillar {
-	x := screenWidth
-	y := rand.Intn(screenHeight - pillarGap - pillarWidth)
-	if y < 0 {
-		y = 0
-	}
-	return &Pillar{x: x, y: y}
-}
-
-// Reset resets the game state.
+// Reset resets the game state. The level generator keeps its current
+// seed across a reset so a daily-challenge run restarts identically.
 func (g *Game) Reset() {
-	g.birdY = screenHeight / 2
-	g.birdDY = 0
+	g.bird = &Bird{x: 50, y: screenHeight / 2}
+	g.levelGen.Restart()
 	g.pillars = []*Pillar{}
-	g.frameCount = 0
+	g.creeps = []*Creep{}
+	g.garlicTimer = 0
 	g.isGameOver = false
 	g.score = 0
 	g.started = false
+	g.tick = 0
+
+	if g.recording {
+		g.replay = &Replay{Seed: g.levelGen.seed}
+	}
+}
+
+// runClient dials a running server's /join endpoint as the second player
+// and runs a ClientGame until the window closes, instead of hosting a
+// local authoritative game.
+func runClient(addr string) {
+	conn, _, err := websocket.DefaultDialer.Dial(addr, nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var ack JoinAck
+	if err := conn.ReadJSON(&ack); err != nil {
+		log.Fatal(err)
+	}
+
+	birdImg, _, err := ebitenutil.NewImageFromFile("flappybird/bird1.png")
+	if err != nil {
+		log.Fatal(err)
+	}
+	birdImage = birdImg
+
+	pillarImg, _, err := ebitenutil.NewImageFromFile("flappybird/pillar.png")
+	if err != nil {
+		log.Fatal(err)
+	}
+	pillarImage = pillarImg
+
+	client := NewClientGame(conn, ack.Slot)
+	if err := ebiten.RunGame(client); err != nil {
+		log.Fatal(err)
+	}
 }
 
 func main() {
-	rand.Seed(time.Now().UnixNano())
+	replayPath := flag.String("replay", "", "path to a replay JSON file to play back deterministically")
+	joinAddr := flag.String("join", "", "websocket address of a running server's /join endpoint, to play as the second networked player instead of hosting a local game")
+	flag.Parse()
+
+	if *joinAddr != "" {
+		runClient(*joinAddr)
+		return
+	}
 
 	game := &Game{
-		pillars: []*Pillar{},
+		bird:           &Bird{x: 50, y: screenHeight / 2},
+		pillars:        []*Pillar{},
+		creeps:         []*Creep{},
+		leaderboardURL: "http://localhost:8080/leaderboard",
+	}
+
+	// Regular play is pinned to today's daily-challenge seed, so every
+	// player's run is the same level and can be fairly ranked on the
+	// leaderboard.
+	seed := dailySeed(time.Now())
+
+	if *replayPath != "" {
+		rep, err := loadReplay(*replayPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		game.levelGen = NewLevelGenerator(rep.Seed)
+		game.input = newScriptedInput(rep)
+	} else {
+		game.levelGen = NewLevelGenerator(rand.Int64())
+		game.SetSeed(seed)
+		game.input = liveInput{}
+		game.recording = true
+		game.replay = &Replay{Seed: game.levelGen.seed}
 	}
 
 	// Load bird image
@@ -176,9 +348,40 @@ func main() {
 	}
 	pillarImage = pillarImg
 
+	// Load creep image
+	creepImg, _, err := ebitenutil.NewImageFromFile("flappybird/creep.png")
+	if err != nil {
+		log.Fatal(err)
+	}
+	creepImage = creepImg
+
+	// Load SFX/music and restore the mute preference from last run
+	if err := audio.Load("flappybird"); err != nil {
+		log.Fatal(err)
+	}
+	audio.SetMuted(loadConfig().Muted)
+	audio.PlayMusic("music", true)
+
 	// Serve the frontend
 	http.Handle("/", http.FileServer(http.Dir("public")))
 
+	// Run the authoritative multiplayer world alongside the local game
+	// window. The host's own keyboard drives player 1 (slot 0) directly;
+	// /ws streams the match to browser spectators, and /join lets a
+	// second Go client register as player 2.
+	serverGame := NewServerGame(rand.Int64(), liveInput{})
+	stopServer := make(chan struct{})
+	go serverGame.Run(stopServer)
+	http.HandleFunc("/ws", serverGame.HandleWS)
+	http.HandleFunc("/join", serverGame.HandleJoin)
+
+	// /leaderboard accepts finished replays (POST) and serves the
+	// current daily-challenge standings (GET). It's scoped to today's
+	// seed, so a replay from a different day is rejected rather than
+	// ranked against it.
+	leaderboard := NewLeaderboard(seed)
+	http.HandleFunc("/leaderboard", leaderboard.HandleLeaderboard)
+
 	// Start the backend server
 	go func() {
 		fmt.Println("Backend server started on :8080")
@@ -0,0 +1,31 @@
+package main
+
+import "image"
+
+// bodyInset shrinks a pillar's hitbox inward from its sprite so the bird
+// can graze the rim without dying.
+const bodyInset = 6
+
+// Pillar represents a pillar object. Gap is carried per-pillar since the
+// LevelGenerator varies it by biome instead of using a single constant.
+// Fields are exported (and tagged) since a Pillar is also the wire
+// representation streamed to spectators and networked players in
+// Snapshot.Pillars.
+type Pillar struct {
+	X   int `json:"x"`
+	Y   int `json:"y"`
+	Gap int `json:"gap"`
+}
+
+// CurrentFrame implements Entity. A pillar has no animation, so it always
+// reports the same body hitboxes for its top and bottom halves, inset
+// from the sprite edges to keep collisions feeling fair. They're tagged
+// HitboxHurt since touching one is damage-dealing.
+func (p *Pillar) CurrentFrame() []Hitbox {
+	top := image.Rect(p.X+bodyInset, 0, p.X+pillarWidth-bodyInset, p.Y)
+	bottom := image.Rect(p.X+bodyInset, p.Y+p.Gap, p.X+pillarWidth-bodyInset, screenHeight)
+	return []Hitbox{
+		{T: HitboxHurt, R: top},
+		{T: HitboxHurt, R: bottom},
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"time"
+)
+
+// leaderboardClient bounds how long a leaderboard submission can stall the
+// caller; postLeaderboard is meant to be run off the game loop, but a hung
+// request shouldn't be able to wedge the process indefinitely either.
+var leaderboardClient = &http.Client{Timeout: 5 * time.Second}
+
+// Replay is enough to deterministically reproduce a run: the level seed
+// plus every frame each tracked key was held, which scriptedInput plays
+// back through the same Game.Update loop a live keyboard would drive.
+type Replay struct {
+	Seed   int64 `json:"seed"`
+	Space  []int `json:"space"`
+	Enter  []int `json:"enter"`
+	Garlic []int `json:"garlic"`
+	Score  int   `json:"score"`
+}
+
+// record appends this frame's held keys to the replay, if any are down.
+func (r *Replay) record(frame int, space, enter, garlic bool) {
+	if space {
+		r.Space = append(r.Space, frame)
+	}
+	if enter {
+		r.Enter = append(r.Enter, frame)
+	}
+	if garlic {
+		r.Garlic = append(r.Garlic, frame)
+	}
+}
+
+// Save writes the replay to disk as JSON.
+func (r *Replay) Save(path string) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// loadReplay reads a replay previously written by Save.
+func loadReplay(path string) (Replay, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Replay{}, err
+	}
+	var rep Replay
+	err = json.Unmarshal(data, &rep)
+	return rep, err
+}
+
+// postLeaderboard submits a finished replay and its score to the backend
+// server's /leaderboard endpoint. Failures are logged, not fatal: a
+// leaderboard submission shouldn't stop the player from seeing the game
+// over screen.
+func postLeaderboard(url string, r *Replay) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		log.Println("marshal replay:", err)
+		return
+	}
+	resp, err := leaderboardClient.Post(url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		log.Println("post leaderboard:", err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		log.Println("post leaderboard:", resp.Status)
+	}
+}
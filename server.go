@@ -0,0 +1,231 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// upgrader promotes both spectator (/ws) and player-2 (/join) HTTP
+// requests to WebSocket connections.
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+// Snapshot is the authoritative world state streamed to spectators and
+// players, framed with a sequence number so a receiver can drop late or
+// duplicate packets instead of rendering them out of order.
+type Snapshot struct {
+	Seq      uint64   `json:"seq"`
+	Bird1Y   float64  `json:"bird1_y"`
+	Bird2Y   float64  `json:"bird2_y"`
+	Pillars  []Pillar `json:"pillars"`
+	Score    int      `json:"score"`
+	GameOver bool     `json:"game_over"`
+}
+
+// InputFrame is one tick of a player's input, tagged with the frame it
+// applies to so the server can discard stale or duplicate packets.
+type InputFrame struct {
+	Frame int  `json:"frame"`
+	Flap  bool `json:"flap"`
+}
+
+// JoinAck is the first message HandleJoin writes back after upgrading a
+// /join connection, telling the client which of the two player slots
+// (and which Snapshot field) it owns.
+type JoinAck struct {
+	Slot int `json:"slot"`
+}
+
+// ServerGame owns the authoritative world for networked play and fans
+// its state out to connected spectators at 60Hz. It reuses the same
+// LevelGenerator, Bird, Pillar and Collide pieces the local game uses.
+// Slot 0 (player 1) is always the host, driven directly by hostInput
+// instead of a websocket, since the host is running in this same
+// process; slot 1 (player 2) is whoever calls /join.
+type ServerGame struct {
+	mu         sync.Mutex
+	levelGen   *LevelGenerator
+	pillars    []*Pillar
+	players    [2]*Bird
+	flap       [2]bool
+	lastFrame  [2]int
+	joined     int
+	seq        uint64
+	score      int
+	isGameOver bool
+
+	hostInput InputSource
+	hostTick  int
+
+	specMu     sync.Mutex
+	spectators map[*websocket.Conn]struct{}
+}
+
+// NewServerGame creates a server-authoritative game seeded the same way
+// as a local game, so a reproduced seed yields the same pillar layout.
+// hostInput drives player 1 (slot 0) directly every tick.
+func NewServerGame(seed int64, hostInput InputSource) *ServerGame {
+	return &ServerGame{
+		levelGen:   NewLevelGenerator(seed),
+		pillars:    []*Pillar{},
+		players:    [2]*Bird{{x: 50, y: screenHeight / 2}, {x: 50, y: screenHeight / 2}},
+		hostInput:  hostInput,
+		joined:     1, // slot 0 is reserved for the host
+		spectators: make(map[*websocket.Conn]struct{}),
+	}
+}
+
+// Run advances the world and broadcasts a snapshot at 60Hz until stop is
+// closed.
+func (sg *ServerGame) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(time.Second / 60)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			sg.tick()
+		}
+	}
+}
+
+// tick advances the world one frame and broadcasts the resulting
+// snapshot.
+func (sg *ServerGame) tick() {
+	sg.mu.Lock()
+	if !sg.isGameOver {
+		sg.hostTick++
+		sg.flap[0] = sg.hostInput.SpaceDown(sg.hostTick)
+
+		for i := range sg.players {
+			bird := sg.players[i]
+			bird.dy += 0.1
+			if sg.flap[i] {
+				bird.dy = -2
+			}
+			bird.y += bird.dy
+		}
+
+		if pillar := sg.levelGen.Tick(); pillar != nil {
+			sg.pillars = append(sg.pillars, pillar)
+		}
+
+		for _, pillar := range sg.pillars {
+			pillar.X -= pillarSpeed
+			if pillar.X < -pillarWidth {
+				sg.pillars = sg.pillars[1:]
+				sg.score++
+			}
+			for _, bird := range sg.players {
+				if aType, bType, hit := Collide(bird, pillar); hit && damages(aType, bType) {
+					sg.isGameOver = true
+				}
+			}
+		}
+	}
+
+	snap := Snapshot{
+		Seq:      sg.seq,
+		Bird1Y:   sg.players[0].y,
+		Bird2Y:   sg.players[1].y,
+		Score:    sg.score,
+		GameOver: sg.isGameOver,
+	}
+	for _, p := range sg.pillars {
+		snap.Pillars = append(snap.Pillars, *p)
+	}
+	sg.seq++
+	sg.mu.Unlock()
+
+	sg.broadcast(snap)
+}
+
+// broadcast pushes a snapshot to every connected spectator, dropping any
+// connection that errors.
+func (sg *ServerGame) broadcast(snap Snapshot) {
+	payload, err := json.Marshal(snap)
+	if err != nil {
+		log.Println("marshal snapshot:", err)
+		return
+	}
+
+	sg.specMu.Lock()
+	defer sg.specMu.Unlock()
+	for conn := range sg.spectators {
+		if err := conn.WriteMessage(websocket.TextMessage, payload); err != nil {
+			conn.Close()
+			delete(sg.spectators, conn)
+		}
+	}
+}
+
+// HandleWS upgrades a spectator connection. Spectators only receive
+// snapshots; they send no input.
+func (sg *ServerGame) HandleWS(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Println("ws upgrade:", err)
+		return
+	}
+
+	sg.specMu.Lock()
+	sg.spectators[conn] = struct{}{}
+	sg.specMu.Unlock()
+}
+
+// HandleJoin registers a player whose bird is simulated server-side from
+// the input frames they send, and streams snapshots back to them over
+// the same connection. Slot 0 belongs to the host, so the first caller
+// here becomes player 2 (slot 1); anyone after that is rejected.
+func (sg *ServerGame) HandleJoin(w http.ResponseWriter, r *http.Request) {
+	sg.mu.Lock()
+	if sg.joined >= len(sg.players) {
+		sg.mu.Unlock()
+		http.Error(w, "game is full", http.StatusServiceUnavailable)
+		return
+	}
+	slot := sg.joined
+	sg.joined++
+	sg.mu.Unlock()
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Println("ws upgrade:", err)
+		return
+	}
+	defer conn.Close()
+
+	if err := conn.WriteJSON(JoinAck{Slot: slot}); err != nil {
+		log.Println("write join ack:", err)
+		return
+	}
+
+	sg.specMu.Lock()
+	sg.spectators[conn] = struct{}{}
+	sg.specMu.Unlock()
+
+	for {
+		var in InputFrame
+		if err := conn.ReadJSON(&in); err != nil {
+			return
+		}
+
+		sg.mu.Lock()
+		if in.Frame <= sg.lastFrame[slot] {
+			sg.mu.Unlock()
+			continue // stale or duplicate packet
+		}
+		sg.lastFrame[slot] = in.Frame
+		sg.flap[slot] = in.Flap
+		sg.mu.Unlock()
+	}
+}